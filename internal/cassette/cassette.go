@@ -0,0 +1,219 @@
+// Package cassette records and replays MCP tool-call traffic as
+// JSON-Lines fixtures, so a gateway run against a real upstream can be
+// captured once and replayed deterministically in later test runs.
+package cassette
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+)
+
+// Entry is one recorded tool call and its response.
+type Entry struct {
+	Timestamp      string          `json:"timestamp"`
+	Tool           string          `json:"tool"`
+	RequestParams  json.RawMessage `json:"request_params"`
+	ResponseStatus int             `json:"response_status"`
+	ResponseBody   json.RawMessage `json:"response_body"`
+	DurationMS     int64           `json:"duration_ms"`
+}
+
+// Key computes the deterministic lookup key for a tool call: the tool name
+// plus its canonicalized params, hashed with SHA-256. Canonicalizing first
+// means the same call made by different client languages (different key
+// order, int vs float encoding) produces the same key.
+func Key(tool string, params json.RawMessage) (string, error) {
+	canon, err := Canonicalize(params)
+	if err != nil {
+		return "", fmt.Errorf("cassette: canonicalize params: %w", err)
+	}
+	h := sha256.New()
+	h.Write([]byte(tool))
+	h.Write([]byte{0})
+	h.Write(canon)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Canonicalize round-trips raw through a generic interface{} decode/encode
+// so object keys sort lexically (encoding/json always sorts map[string]any
+// keys) and numeric encoding is normalized, regardless of how the original
+// caller formatted it.
+func Canonicalize(raw json.RawMessage) (json.RawMessage, error) {
+	if len(raw) == 0 {
+		return []byte("null"), nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// Cassette is an in-memory, key-indexed set of Entries, backed by a
+// JSON-Lines file. Safe for concurrent use: a recordedEndpoint shares one
+// Cassette across however many in-flight HTTP requests net/http hands it.
+type Cassette struct {
+	mu      sync.Mutex
+	entries map[string]Entry
+	order   []string
+	appendF *os.File
+	appendW *bufio.Writer
+}
+
+// New builds an empty Cassette.
+func New() *Cassette {
+	return &Cassette{entries: make(map[string]Entry)}
+}
+
+// Get looks up the Entry recorded for key.
+func (c *Cassette) Get(key string) (Entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	return e, ok
+}
+
+// OpenAppend opens path for appending and arranges for every subsequent Put
+// to be written through to it immediately, flushed before Put returns. This
+// makes a recording session crash-safe: a kill -9 mid-session loses at most
+// the call currently in flight, not everything recorded so far. Call Load
+// first to pick up any entries already on disk, then OpenAppend to start
+// capturing new ones; Close stops appending and releases the file.
+func (c *Cassette) OpenAppend(path string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("cassette: open %s: %w", path, err)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.appendF = f
+	c.appendW = bufio.NewWriter(f)
+	return nil
+}
+
+// Close flushes and closes the file opened by OpenAppend, if any.
+func (c *Cassette) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.appendW == nil {
+		return nil
+	}
+	if err := c.appendW.Flush(); err != nil {
+		return fmt.Errorf("cassette: flush: %w", err)
+	}
+	return c.appendF.Close()
+}
+
+// Put records or overwrites the Entry for key, preserving first-seen order
+// for new keys so Save output stays stable. If OpenAppend was called, e is
+// also written and flushed to the append file before Put returns.
+func (c *Cassette) Put(key string, e Entry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, exists := c.entries[key]; !exists {
+		c.order = append(c.order, key)
+	}
+	c.entries[key] = e
+
+	if c.appendW == nil {
+		return nil
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("cassette: marshal entry: %w", err)
+	}
+	if _, err := c.appendW.Write(append(b, '\n')); err != nil {
+		return fmt.Errorf("cassette: append write: %w", err)
+	}
+	return c.appendW.Flush()
+}
+
+// Entries returns every recorded entry in insertion order.
+func (c *Cassette) Entries() []Entry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make([]Entry, 0, len(c.order))
+	for _, key := range c.order {
+		out = append(out, c.entries[key])
+	}
+	return out
+}
+
+// Load reads a JSON-Lines cassette file, one Entry per line, keyed by
+// Key(entry.Tool, entry.RequestParams). A missing file loads as empty so a
+// fresh recording session can start from scratch.
+func Load(path string) (*Cassette, error) {
+	c := New()
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cassette: open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 8*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(line, &e); err != nil {
+			return nil, fmt.Errorf("cassette: parse %s: %w", path, err)
+		}
+		key, err := Key(e.Tool, e.RequestParams)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.Put(key, e); err != nil {
+			return nil, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("cassette: read %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// Save writes every entry back out as JSON-Lines, in insertion order.
+func (c *Cassette) Save(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("cassette: create %s: %w", path, err)
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	for _, e := range c.Entries() {
+		b, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("cassette: marshal entry: %w", err)
+		}
+		if _, err := w.Write(append(b, '\n')); err != nil {
+			return fmt.Errorf("cassette: write %s: %w", path, err)
+		}
+	}
+	return w.Flush()
+}
+
+// Keys returns every key in the cassette, sorted, for diffing.
+func (c *Cassette) Keys() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make([]string, 0, len(c.entries))
+	for k := range c.entries {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,187 @@
+package cassette
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+)
+
+func TestKeyCanonicalizesParams(t *testing.T) {
+	a, err := Key("tool", json.RawMessage(`{"b":2,"a":1}`))
+	if err != nil {
+		t.Fatalf("Key: %v", err)
+	}
+	b, err := Key("tool", json.RawMessage(`{"a":1.0,"b":2.0}`))
+	if err != nil {
+		t.Fatalf("Key: %v", err)
+	}
+	if a != b {
+		t.Errorf("Key() = %q, %q, want equal for reordered/differently-encoded params", a, b)
+	}
+
+	c, err := Key("other-tool", json.RawMessage(`{"a":1,"b":2}`))
+	if err != nil {
+		t.Fatalf("Key: %v", err)
+	}
+	if a == c {
+		t.Error("Key() matched across different tool names")
+	}
+}
+
+func TestCassettePutGet(t *testing.T) {
+	c := New()
+	if _, ok := c.Get("missing"); ok {
+		t.Fatal("Get on empty cassette returned ok=true")
+	}
+
+	entry := Entry{Tool: "foo", ResponseStatus: 200, ResponseBody: json.RawMessage(`{"ok":true}`)}
+	if err := c.Put("k1", entry); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	got, ok := c.Get("k1")
+	if !ok {
+		t.Fatal("Get after Put returned ok=false")
+	}
+	if got.Tool != "foo" {
+		t.Errorf("Get().Tool = %q, want %q", got.Tool, "foo")
+	}
+}
+
+func TestCassetteLoadSaveRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cassette.jsonl")
+
+	alpha := Entry{Tool: "alpha", RequestParams: json.RawMessage(`{"a":1}`), ResponseStatus: 200, ResponseBody: json.RawMessage(`{"a":1}`)}
+	beta := Entry{Tool: "beta", RequestParams: json.RawMessage(`{"b":2}`), ResponseStatus: 200, ResponseBody: json.RawMessage(`{"b":2}`)}
+
+	c := New()
+	for _, e := range []Entry{alpha, beta} {
+		key, err := Key(e.Tool, e.RequestParams)
+		if err != nil {
+			t.Fatalf("Key: %v", err)
+		}
+		mustPut(t, c, key, e)
+	}
+
+	if err := c.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	for _, want := range []Entry{alpha, beta} {
+		key, err := Key(want.Tool, want.RequestParams)
+		if err != nil {
+			t.Fatalf("Key: %v", err)
+		}
+		got, ok := loaded.Get(key)
+		if !ok {
+			t.Fatalf("Load missing entry for tool %q", want.Tool)
+		}
+		if got.Tool != want.Tool {
+			t.Errorf("loaded entry Tool = %q, want %q", got.Tool, want.Tool)
+		}
+	}
+}
+
+func TestCassetteLoadMissingFileIsEmpty(t *testing.T) {
+	c, err := Load(filepath.Join(t.TempDir(), "does-not-exist.jsonl"))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(c.Entries()) != 0 {
+		t.Errorf("Entries() = %v, want empty", c.Entries())
+	}
+}
+
+func TestCassetteOpenAppendWritesImmediately(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cassette.jsonl")
+
+	alpha := Entry{Tool: "alpha", RequestParams: json.RawMessage(`{"a":1}`), ResponseStatus: 200, ResponseBody: json.RawMessage(`{"a":1}`)}
+	beta := Entry{Tool: "beta", RequestParams: json.RawMessage(`{"b":2}`), ResponseStatus: 200, ResponseBody: json.RawMessage(`{"b":2}`)}
+
+	c := New()
+	if err := c.OpenAppend(path); err != nil {
+		t.Fatalf("OpenAppend: %v", err)
+	}
+
+	alphaKey, err := Key(alpha.Tool, alpha.RequestParams)
+	if err != nil {
+		t.Fatalf("Key: %v", err)
+	}
+	mustPut(t, c, alphaKey, alpha)
+
+	// The entry must already be on disk before Close, simulating a crash
+	// between recordings: a second Load of the same path sees it.
+	reread, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load mid-append: %v", err)
+	}
+	if _, ok := reread.Get(alphaKey); !ok {
+		t.Fatal("entry written by Put was not durable before Close")
+	}
+
+	betaKey, err := Key(beta.Tool, beta.RequestParams)
+	if err != nil {
+		t.Fatalf("Key: %v", err)
+	}
+	mustPut(t, c, betaKey, beta)
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	final, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load after Close: %v", err)
+	}
+	if len(final.Entries()) != 2 {
+		t.Errorf("Entries() after Close = %d, want 2", len(final.Entries()))
+	}
+}
+
+func TestCompare(t *testing.T) {
+	a := New()
+	mustPut(t, a, "k1", Entry{Tool: "same", ResponseStatus: 200, ResponseBody: json.RawMessage(`{"v":1}`)})
+	mustPut(t, a, "k2", Entry{Tool: "changed", ResponseStatus: 200, ResponseBody: json.RawMessage(`{"v":1}`)})
+	mustPut(t, a, "k3", Entry{Tool: "only-a", ResponseStatus: 200, ResponseBody: json.RawMessage(`{}`)})
+
+	b := New()
+	mustPut(t, b, "k1", Entry{Tool: "same", ResponseStatus: 200, ResponseBody: json.RawMessage(`{"v":1}`)})
+	mustPut(t, b, "k2", Entry{Tool: "changed", ResponseStatus: 200, ResponseBody: json.RawMessage(`{"v":2}`)})
+	mustPut(t, b, "k4", Entry{Tool: "only-b", ResponseStatus: 200, ResponseBody: json.RawMessage(`{}`)})
+
+	diffs := Compare(a, b)
+	if len(diffs) != 3 {
+		t.Fatalf("Compare() returned %d diffs, want 3: %+v", len(diffs), diffs)
+	}
+
+	byKey := make(map[string]Diff, len(diffs))
+	for _, d := range diffs {
+		byKey[d.Key] = d
+	}
+
+	if d, ok := byKey["k2"]; !ok || !d.Changed {
+		t.Errorf("k2 diff = %+v, want Changed=true", d)
+	}
+	if d, ok := byKey["k3"]; !ok || d.OnlyIn != "a" {
+		t.Errorf("k3 diff = %+v, want OnlyIn=a", d)
+	}
+	if d, ok := byKey["k4"]; !ok || d.OnlyIn != "b" {
+		t.Errorf("k4 diff = %+v, want OnlyIn=b", d)
+	}
+	if _, ok := byKey["k1"]; ok {
+		t.Error("identical entry k1 should not appear in the diff")
+	}
+}
+
+func mustPut(t *testing.T, c *Cassette, key string, e Entry) {
+	t.Helper()
+	if err := c.Put(key, e); err != nil {
+		t.Fatalf("Put(%q): %v", key, err)
+	}
+}
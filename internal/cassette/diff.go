@@ -0,0 +1,59 @@
+package cassette
+
+import "fmt"
+
+// Diff describes how two cassettes differ for a single key.
+type Diff struct {
+	Key     string
+	OnlyIn  string // "a" or "b", set when the key is missing from the other
+	Changed bool   // both have the key but the recorded response differs
+	A, B    Entry
+}
+
+// Compare reports every key that's missing from one side or whose response
+// body/status changed between a and b.
+func Compare(a, b *Cassette) []Diff {
+	var diffs []Diff
+
+	seen := make(map[string]bool)
+	for _, key := range a.Keys() {
+		seen[key] = true
+		bEntry, ok := b.Get(key)
+		if !ok {
+			diffs = append(diffs, Diff{Key: key, OnlyIn: "a", A: mustGet(a, key)})
+			continue
+		}
+		aEntry := mustGet(a, key)
+		if string(aEntry.ResponseBody) != string(bEntry.ResponseBody) || aEntry.ResponseStatus != bEntry.ResponseStatus {
+			diffs = append(diffs, Diff{Key: key, Changed: true, A: aEntry, B: bEntry})
+		}
+	}
+	for _, key := range b.Keys() {
+		if !seen[key] {
+			diffs = append(diffs, Diff{Key: key, OnlyIn: "b", B: mustGet(b, key)})
+		}
+	}
+	return diffs
+}
+
+func mustGet(c *Cassette, key string) Entry {
+	e, _ := c.Get(key)
+	return e
+}
+
+// String renders a Diff as a single human-readable line.
+func (d Diff) String() string {
+	switch {
+	case d.OnlyIn != "":
+		return fmt.Sprintf("only in %s: %s (tool=%s)", d.OnlyIn, d.Key, onlyEntry(d).Tool)
+	default:
+		return fmt.Sprintf("changed: %s (tool=%s) status %d->%d", d.Key, d.A.Tool, d.A.ResponseStatus, d.B.ResponseStatus)
+	}
+}
+
+func onlyEntry(d Diff) Entry {
+	if d.OnlyIn == "a" {
+		return d.A
+	}
+	return d.B
+}
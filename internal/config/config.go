@@ -0,0 +1,141 @@
+// Package config defines the gateway's YAML configuration shape: the set
+// of endpoints it should run and how each one is reached.
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Known endpoint kinds.
+const (
+	KindMockMCP  = "mock-mcp"
+	KindEcho     = "echo"
+	KindRecorded = "recorded"
+)
+
+// Record/replay modes for a KindRecorded endpoint.
+const (
+	ModeRecord      = "record"
+	ModeReplay      = "replay"
+	ModePassthrough = "passthrough"
+	ModeEcho        = "echo"
+)
+
+// DefaultListen is used for an endpoint that doesn't set Listen.
+const DefaultListen = ":8080"
+
+// TLS holds the certificate/key pair an endpoint should serve over HTTPS.
+// An endpoint with a nil TLS config serves plain HTTP.
+type TLS struct {
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+}
+
+// Tool describes one tool a mock-mcp endpoint should advertise. It's
+// intentionally thin for now; the scenario engine attaches richer behavior
+// per tool separately. When an endpoint declares any Tools, a tools/call
+// for an undeclared name is rejected instead of falling through to echo.
+type Tool struct {
+	Name string `yaml:"name"`
+}
+
+// Endpoint is the config for a single backend the orchestrator should run.
+type Endpoint struct {
+	Name          string `yaml:"name"`
+	Kind          string `yaml:"kind"`
+	Listen        string `yaml:"listen"`
+	TLS           *TLS   `yaml:"tls,omitempty"`
+	Tools         []Tool `yaml:"tools,omitempty"`
+	Scenarios     string `yaml:"scenarios,omitempty"`
+	ParallelBatch bool   `yaml:"parallel_batch,omitempty"` // dispatch a JSON-RPC batch's requests concurrently instead of in order
+
+	// The following apply only to Kind == KindRecorded.
+	Mode     string `yaml:"mode,omitempty"`     // record|replay|passthrough|echo
+	Upstream string `yaml:"upstream,omitempty"` // real MCP upstream, for record/passthrough
+	Cassette string `yaml:"cassette,omitempty"` // JSON-Lines fixture path, for record/replay
+	Strict   bool   `yaml:"strict,omitempty"`   // replay: answer an unknown hash with a JSON-RPC -32601 error (the HTTP status stays 200) instead of falling through to echo
+}
+
+// Admin is the config for the gateway's own aggregated health server.
+type Admin struct {
+	Listen string `yaml:"listen"`
+}
+
+// Config is the top-level shape of an endpoints YAML file.
+type Config struct {
+	Admin     Admin      `yaml:"admin"`
+	HTTP      HTTP       `yaml:"http"`
+	Endpoints []Endpoint `yaml:"endpoints"`
+}
+
+// Default returns the gateway's historical single-endpoint topology: one
+// mock-mcp backend on :8080, used when no config file is given.
+func Default() *Config {
+	cfg := &Config{
+		Endpoints: []Endpoint{
+			{Name: "default", Kind: KindMockMCP, Listen: DefaultListen},
+		},
+	}
+	cfg.SetDefaults()
+	return cfg
+}
+
+// Load reads and parses a YAML config file at path, applying defaults to
+// any field left unset.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config: read %s: %w", path, err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("config: parse %s: %w", path, err)
+	}
+	cfg.SetDefaults()
+
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("config: %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// SetDefaults fills in any field left unset. Load and Default already call
+// this; callers building a Config by hand (e.g. from CLI flags) should call
+// it themselves before passing the Config on.
+func (c *Config) SetDefaults() {
+	if c.Admin.Listen == "" {
+		c.Admin.Listen = ":9090"
+	}
+	c.HTTP.setDefaults()
+	c.HTTP.ApplyEnv()
+	for i := range c.Endpoints {
+		ep := &c.Endpoints[i]
+		if ep.Kind == "" {
+			ep.Kind = KindMockMCP
+		}
+		if ep.Listen == "" {
+			ep.Listen = DefaultListen
+		}
+		if ep.Kind == KindRecorded && ep.Mode == "" {
+			ep.Mode = ModeEcho
+		}
+	}
+}
+
+func (c *Config) validate() error {
+	seen := make(map[string]bool, len(c.Endpoints))
+	for _, ep := range c.Endpoints {
+		if ep.Name == "" {
+			return fmt.Errorf("endpoint missing name")
+		}
+		if seen[ep.Name] {
+			return fmt.Errorf("duplicate endpoint name %q", ep.Name)
+		}
+		seen[ep.Name] = true
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// HTTP tunes every HTTP listener the gateway starts (endpoints and the
+// admin server alike), mirroring the kind of http block a production proxy
+// ships with: explicit timeouts, a header-size cap, and optional CORS.
+// CORS headers are sent only by the mock-mcp and recorded endpoints; the
+// echo endpoint and the admin server do not send them.
+type HTTP struct {
+	ReadTimeout       time.Duration `yaml:"read_timeout"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+	WriteTimeout      time.Duration `yaml:"write_timeout"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout"`
+	MaxHeaderBytes    int           `yaml:"max_header_bytes"`
+	ShutdownGrace     time.Duration `yaml:"shutdown_grace"`
+
+	CORSOrigin  string `yaml:"cors_origin,omitempty"`
+	CORSMethods string `yaml:"cors_methods,omitempty"`
+	CORSHeaders string `yaml:"cors_headers,omitempty"`
+}
+
+// DefaultHTTP returns the gateway's default HTTP tuning.
+func DefaultHTTP() HTTP {
+	return HTTP{
+		ReadTimeout:       30 * time.Second,
+		ReadHeaderTimeout: 10 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		IdleTimeout:       120 * time.Second,
+		MaxHeaderBytes:    1 << 20,
+		ShutdownGrace:     10 * time.Second,
+	}
+}
+
+func (h *HTTP) setDefaults() {
+	def := DefaultHTTP()
+	if h.ReadTimeout == 0 {
+		h.ReadTimeout = def.ReadTimeout
+	}
+	if h.ReadHeaderTimeout == 0 {
+		h.ReadHeaderTimeout = def.ReadHeaderTimeout
+	}
+	if h.WriteTimeout == 0 {
+		h.WriteTimeout = def.WriteTimeout
+	}
+	if h.IdleTimeout == 0 {
+		h.IdleTimeout = def.IdleTimeout
+	}
+	if h.MaxHeaderBytes == 0 {
+		h.MaxHeaderBytes = def.MaxHeaderBytes
+	}
+	if h.ShutdownGrace == 0 {
+		h.ShutdownGrace = def.ShutdownGrace
+	}
+}
+
+// ApplyEnv overrides HTTP fields from MOCKMCP_HTTP_* environment variables,
+// so an operator can tune timeouts without editing the YAML config.
+func (h *HTTP) ApplyEnv() {
+	if v, ok := durationEnv("MOCKMCP_HTTP_READ_TIMEOUT"); ok {
+		h.ReadTimeout = v
+	}
+	if v, ok := durationEnv("MOCKMCP_HTTP_READ_HEADER_TIMEOUT"); ok {
+		h.ReadHeaderTimeout = v
+	}
+	if v, ok := durationEnv("MOCKMCP_HTTP_WRITE_TIMEOUT"); ok {
+		h.WriteTimeout = v
+	}
+	if v, ok := durationEnv("MOCKMCP_HTTP_IDLE_TIMEOUT"); ok {
+		h.IdleTimeout = v
+	}
+	if v, ok := durationEnv("MOCKMCP_HTTP_SHUTDOWN_GRACE"); ok {
+		h.ShutdownGrace = v
+	}
+	if v, ok := intEnv("MOCKMCP_HTTP_MAX_HEADER_BYTES"); ok {
+		h.MaxHeaderBytes = v
+	}
+	if v := os.Getenv("MOCKMCP_HTTP_CORS_ORIGIN"); v != "" {
+		h.CORSOrigin = v
+	}
+	if v := os.Getenv("MOCKMCP_HTTP_CORS_METHODS"); v != "" {
+		h.CORSMethods = v
+	}
+	if v := os.Getenv("MOCKMCP_HTTP_CORS_HEADERS"); v != "" {
+		h.CORSHeaders = v
+	}
+}
+
+func durationEnv(key string) (time.Duration, bool) {
+	v := os.Getenv(key)
+	if v == "" {
+		return 0, false
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, false
+	}
+	return d, true
+}
+
+func intEnv(key string) (int, bool) {
+	v := os.Getenv(key)
+	if v == "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
@@ -0,0 +1,92 @@
+package endpoint
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/jk-nd/noumena-mcp-gateway-poc/internal/config"
+	"github.com/jk-nd/noumena-mcp-gateway-poc/internal/rpc"
+)
+
+// echoEndpoint is a plain HTTP backend that echoes back whatever body it
+// receives, with no JSON-RPC envelope. Useful for exercising the gateway
+// against a "dumb" upstream that doesn't speak MCP at all.
+type echoEndpoint struct {
+	name    string
+	addr    string
+	cfg     rpc.ServerConfig
+	logger  rpc.Logger
+	server  *http.Server
+	done    chan struct{}
+	healthy atomic.Bool
+}
+
+func newEchoEndpoint(cfg config.Endpoint, httpCfg config.HTTP, logger rpc.Logger) *echoEndpoint {
+	return &echoEndpoint{
+		name:   cfg.Name,
+		addr:   cfg.Listen,
+		cfg:    toServerConfig(httpCfg, cfg.TLS),
+		logger: logger,
+	}
+}
+
+func (e *echoEndpoint) Name() string { return e.name }
+
+func (e *echoEndpoint) Start(ctx context.Context) error {
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"path": r.URL.Path,
+			"echo": json.RawMessage(body),
+		})
+	}))
+
+	e.server = &http.Server{
+		Addr:              e.addr,
+		Handler:           handler,
+		ReadTimeout:       e.cfg.ReadTimeout,
+		ReadHeaderTimeout: e.cfg.ReadHeaderTimeout,
+		WriteTimeout:      e.cfg.WriteTimeout,
+		IdleTimeout:       e.cfg.IdleTimeout,
+		MaxHeaderBytes:    e.cfg.MaxHeaderBytes,
+	}
+	e.done = make(chan struct{})
+
+	go func() {
+		defer close(e.done)
+		defer e.healthy.Store(false)
+		var err error
+		if e.cfg.TLSCertFile != "" && e.cfg.TLSKeyFile != "" {
+			err = e.server.ListenAndServeTLS(e.cfg.TLSCertFile, e.cfg.TLSKeyFile)
+		} else {
+			err = e.server.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
+			e.logger.Printf("endpoint %q: %v", e.name, err)
+		}
+	}()
+
+	awaitHealthy(e.done, &e.healthy)
+	return nil
+}
+
+func (e *echoEndpoint) Stop(ctx context.Context) error {
+	if e.server == nil {
+		return nil
+	}
+	shutdownCtx, cancel := context.WithTimeout(ctx, e.cfg.ShutdownGrace)
+	defer cancel()
+	if err := e.server.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	<-e.done
+	return nil
+}
+
+func (e *echoEndpoint) Healthy() bool {
+	return e.healthy.Load()
+}
@@ -0,0 +1,44 @@
+// Package endpoint models the mock backends the gateway fans requests out
+// to, and an Orchestrator that owns their lifecycle as a group.
+package endpoint
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// startupGrace is how long awaitHealthy waits before reporting an endpoint
+// healthy, giving its transport time to bind its listener.
+const startupGrace = 50 * time.Millisecond
+
+// awaitHealthy blocks until either done closes or startupGrace elapses,
+// marking healthy true in the latter case.
+//
+// It must not blindly mark healthy after the grace period: if the server
+// already exited (e.g. the listener failed to bind), done is already
+// closed and healthy was just set false by the goroutine that closes it —
+// racing a blind Store(true) here would clobber that. All three Endpoint
+// implementations share this exact race, so it's factored out once instead
+// of copy-pasted.
+func awaitHealthy(done <-chan struct{}, healthy *atomic.Bool) {
+	select {
+	case <-done:
+	case <-time.After(startupGrace):
+		healthy.Store(true)
+	}
+}
+
+// Endpoint is a single running backend: a mock-mcp server, a plain echo
+// server, or a recorded-traffic recorder/replayer.
+type Endpoint interface {
+	// Name identifies the endpoint, matching its config entry.
+	Name() string
+	// Start brings the endpoint up and returns once it's accepting
+	// traffic, or once ctx is canceled. It must not block past that.
+	Start(ctx context.Context) error
+	// Stop tears the endpoint down, respecting ctx's deadline.
+	Stop(ctx context.Context) error
+	// Healthy reports whether the endpoint is currently serving traffic.
+	Healthy() bool
+}
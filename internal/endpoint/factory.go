@@ -0,0 +1,23 @@
+package endpoint
+
+import (
+	"fmt"
+
+	"github.com/jk-nd/noumena-mcp-gateway-poc/internal/config"
+	"github.com/jk-nd/noumena-mcp-gateway-poc/internal/rpc"
+)
+
+// New builds the Endpoint described by cfg, tuning its HTTP listener per
+// httpCfg.
+func New(cfg config.Endpoint, httpCfg config.HTTP, logger rpc.Logger) (Endpoint, error) {
+	switch cfg.Kind {
+	case config.KindMockMCP:
+		return newMockMCPEndpoint(cfg, httpCfg, logger)
+	case config.KindEcho:
+		return newEchoEndpoint(cfg, httpCfg, logger), nil
+	case config.KindRecorded:
+		return newRecordedEndpoint(cfg, httpCfg, logger)
+	default:
+		return nil, fmt.Errorf("endpoint %q: unknown kind %q", cfg.Name, cfg.Kind)
+	}
+}
@@ -0,0 +1,142 @@
+package endpoint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/jk-nd/noumena-mcp-gateway-poc/internal/config"
+	"github.com/jk-nd/noumena-mcp-gateway-poc/internal/rpc"
+	"github.com/jk-nd/noumena-mcp-gateway-poc/internal/scenarios"
+)
+
+// scenarioAdminPath is where a running mock-mcp endpoint accepts runtime
+// scenario injection.
+const scenarioAdminPath = "/_mock/scenarios"
+
+// mockMCPEndpoint serves MCP tool calls over JSON-RPC. Calls are checked
+// against a ScenarioStore first; anything unmatched falls back to echoing
+// the arguments, the way the original single-process mock always did.
+type mockMCPEndpoint struct {
+	name    string
+	server  *rpc.Server
+	logger  rpc.Logger
+	store   *scenarios.Store
+	tools   map[string]bool // declared tool names; nil/empty means any tool is allowed
+	cancel  context.CancelFunc
+	done    chan struct{}
+	healthy atomic.Bool
+}
+
+func newMockMCPEndpoint(cfg config.Endpoint, httpCfg config.HTTP, logger rpc.Logger) (*mockMCPEndpoint, error) {
+	e := &mockMCPEndpoint{name: cfg.Name, logger: logger, store: scenarios.NewStore()}
+
+	if len(cfg.Tools) > 0 {
+		e.tools = make(map[string]bool, len(cfg.Tools))
+		for _, t := range cfg.Tools {
+			e.tools[t.Name] = true
+		}
+	}
+
+	if cfg.Scenarios != "" {
+		if err := scenarios.LoadFileInto(e.store, cfg.Scenarios); err != nil {
+			return nil, err
+		}
+	}
+
+	e.server = rpc.New(
+		rpc.WithLogger(logger),
+		rpc.WithMiddleware(rpc.Logging(logger)),
+		rpc.WithParallelBatch(cfg.ParallelBatch),
+		rpc.WithTransport(rpc.NewHTTPTransport(
+			cfg.Listen,
+			rpc.WithExtraHandler(scenarioAdminPath, scenarios.AdminHandler(e.store)),
+			rpc.WithServerConfig(toServerConfig(httpCfg, cfg.TLS)),
+		)),
+	)
+	e.server.Register("tools/call", e.handleToolCall)
+	return e, nil
+}
+
+func (e *mockMCPEndpoint) Name() string { return e.name }
+
+func (e *mockMCPEndpoint) Start(ctx context.Context) error {
+	runCtx, cancel := context.WithCancel(ctx)
+	e.cancel = cancel
+	e.done = make(chan struct{})
+
+	go func() {
+		defer close(e.done)
+		defer e.healthy.Store(false)
+		if err := e.server.Run(runCtx); err != nil && runCtx.Err() == nil {
+			e.logger.Printf("endpoint %q: %v", e.name, err)
+		}
+	}()
+
+	awaitHealthy(e.done, &e.healthy)
+	return nil
+}
+
+func (e *mockMCPEndpoint) Stop(ctx context.Context) error {
+	if e.cancel == nil {
+		return nil
+	}
+	e.cancel()
+	select {
+	case <-e.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (e *mockMCPEndpoint) Healthy() bool {
+	return e.healthy.Load()
+}
+
+// toolCallParams is the params object for the "tools/call" method, matching
+// the MCP convention of a tool name plus an arbitrary arguments payload.
+type toolCallParams struct {
+	Name      string                 `json:"name"`
+	Arguments map[string]interface{} `json:"arguments"`
+}
+
+func (e *mockMCPEndpoint) handleToolCall(ctx context.Context, raw *json.RawMessage) (any, error) {
+	var params toolCallParams
+	if raw != nil {
+		if err := json.Unmarshal(*raw, &params); err != nil {
+			return nil, rpc.NewError(rpc.CodeInvalidParams, "invalid params", err.Error())
+		}
+	}
+	if params.Name == "" {
+		return nil, rpc.NewError(rpc.CodeInvalidParams, "missing tool name", nil)
+	}
+	if e.tools != nil && !e.tools[params.Name] {
+		return nil, rpc.NewError(rpc.CodeMethodNotFound, fmt.Sprintf("tool not declared: %s", params.Name), nil)
+	}
+
+	if resp, ok := e.store.Match(params.Name, params.Arguments); ok {
+		return applyScenarioResponse(ctx, resp)
+	}
+
+	return echoResponse(params.Name, params.Arguments), nil
+}
+
+// applyScenarioResponse honors a matched scenario's delay and maps its
+// status into either an RPC result or an RPC error.
+func applyScenarioResponse(ctx context.Context, resp scenarios.Response) (any, error) {
+	if resp.DelayMS > 0 {
+		select {
+		case <-time.After(time.Duration(resp.DelayMS) * time.Millisecond):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if resp.Status >= 400 {
+		return nil, rpc.NewError(rpc.CodeInternalError, fmt.Sprintf("scenario status %d", resp.Status), resp.Body)
+	}
+	return resp.Body, nil
+}
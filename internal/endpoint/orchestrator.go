@@ -0,0 +1,82 @@
+package endpoint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// Orchestrator owns a set of Endpoints as a group: it serializes their
+// Start/Stop calls, fans a shared context out to each one for cancellation,
+// and aggregates their health into a single /health response.
+type Orchestrator struct {
+	mu        sync.Mutex
+	endpoints []Endpoint
+}
+
+// NewOrchestrator builds an Orchestrator over the given endpoints.
+func NewOrchestrator(endpoints ...Endpoint) *Orchestrator {
+	return &Orchestrator{endpoints: endpoints}
+}
+
+// Start brings every endpoint up in order, stopping at the first failure.
+func (o *Orchestrator) Start(ctx context.Context) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for _, ep := range o.endpoints {
+		if err := ep.Start(ctx); err != nil {
+			return fmt.Errorf("starting endpoint %q: %w", ep.Name(), err)
+		}
+	}
+	return nil
+}
+
+// Stop tears every endpoint down in order, continuing past failures and
+// returning the first error encountered.
+func (o *Orchestrator) Stop(ctx context.Context) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var firstErr error
+	for _, ep := range o.endpoints {
+		if err := ep.Stop(ctx); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("stopping endpoint %q: %w", ep.Name(), err)
+		}
+	}
+	return firstErr
+}
+
+// Health returns each endpoint's current health, keyed by name.
+func (o *Orchestrator) Health() map[string]bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	health := make(map[string]bool, len(o.endpoints))
+	for _, ep := range o.endpoints {
+		health[ep.Name()] = ep.Healthy()
+	}
+	return health
+}
+
+// HealthHandler serves the aggregated health of every endpoint, responding
+// 200 only when all of them are healthy and 503 otherwise.
+func (o *Orchestrator) HealthHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		health := o.Health()
+
+		status := http.StatusOK
+		for _, healthy := range health {
+			if !healthy {
+				status = http.StatusServiceUnavailable
+				break
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(map[string]interface{}{"endpoints": health})
+	}
+}
@@ -0,0 +1,150 @@
+package endpoint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+)
+
+// stubEndpoint is a minimal Endpoint double for exercising Orchestrator
+// without standing up a real HTTP listener.
+type stubEndpoint struct {
+	name     string
+	startErr error
+	stopErr  error
+	healthy  bool
+	started  bool
+	stopped  bool
+}
+
+func (s *stubEndpoint) Name() string { return s.name }
+
+func (s *stubEndpoint) Start(ctx context.Context) error {
+	s.started = true
+	if s.startErr != nil {
+		return s.startErr
+	}
+	s.healthy = true
+	return nil
+}
+
+func (s *stubEndpoint) Stop(ctx context.Context) error {
+	s.stopped = true
+	s.healthy = false
+	return s.stopErr
+}
+
+func (s *stubEndpoint) Healthy() bool { return s.healthy }
+
+func TestOrchestratorHealth(t *testing.T) {
+	tests := []struct {
+		name string
+		eps  []*stubEndpoint
+		want map[string]bool
+	}{
+		{
+			name: "all healthy",
+			eps:  []*stubEndpoint{{name: "a", healthy: true}, {name: "b", healthy: true}},
+			want: map[string]bool{"a": true, "b": true},
+		},
+		{
+			name: "one unhealthy",
+			eps:  []*stubEndpoint{{name: "a", healthy: true}, {name: "b", healthy: false}},
+			want: map[string]bool{"a": true, "b": false},
+		},
+		{
+			name: "empty",
+			eps:  nil,
+			want: map[string]bool{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoints := make([]Endpoint, len(tt.eps))
+			for i, ep := range tt.eps {
+				endpoints[i] = ep
+			}
+			o := NewOrchestrator(endpoints...)
+
+			got := o.Health()
+			if len(got) != len(tt.want) {
+				t.Fatalf("Health() = %v, want %v", got, tt.want)
+			}
+			for name, healthy := range tt.want {
+				if got[name] != healthy {
+					t.Errorf("Health()[%q] = %v, want %v", name, got[name], healthy)
+				}
+			}
+		})
+	}
+}
+
+func TestOrchestratorHealthHandler(t *testing.T) {
+	healthy := &stubEndpoint{name: "healthy", healthy: true}
+	unhealthy := &stubEndpoint{name: "unhealthy", healthy: false}
+
+	t.Run("200 when all healthy", func(t *testing.T) {
+		o := NewOrchestrator(healthy)
+		rec := httptest.NewRecorder()
+		o.HealthHandler()(rec, httptest.NewRequest("GET", "/health", nil))
+
+		if rec.Code != 200 {
+			t.Fatalf("status = %d, want 200", rec.Code)
+		}
+	})
+
+	t.Run("503 when any endpoint is unhealthy", func(t *testing.T) {
+		o := NewOrchestrator(healthy, unhealthy)
+		rec := httptest.NewRecorder()
+		o.HealthHandler()(rec, httptest.NewRequest("GET", "/health", nil))
+
+		if rec.Code != 503 {
+			t.Fatalf("status = %d, want 503", rec.Code)
+		}
+
+		var body struct {
+			Endpoints map[string]bool `json:"endpoints"`
+		}
+		if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body.Endpoints["unhealthy"] {
+			t.Errorf("body reports %q healthy, want false", "unhealthy")
+		}
+	})
+}
+
+func TestOrchestratorStartStopsAtFirstFailure(t *testing.T) {
+	ok := &stubEndpoint{name: "ok"}
+	bad := &stubEndpoint{name: "bad", startErr: errors.New("bind failed")}
+	never := &stubEndpoint{name: "never"}
+
+	o := NewOrchestrator(ok, bad, never)
+	if err := o.Start(context.Background()); err == nil {
+		t.Fatal("Start() = nil, want error from the failing endpoint")
+	}
+
+	if !ok.started {
+		t.Error("endpoint before the failure was never started")
+	}
+	if never.started {
+		t.Error("endpoint after the failure should not have been started")
+	}
+}
+
+func TestOrchestratorStopContinuesPastFailures(t *testing.T) {
+	first := &stubEndpoint{name: "first", stopErr: errors.New("stop failed")}
+	second := &stubEndpoint{name: "second"}
+
+	o := NewOrchestrator(first, second)
+	err := o.Stop(context.Background())
+	if err == nil {
+		t.Fatal("Stop() = nil, want the first endpoint's error")
+	}
+	if !second.stopped {
+		t.Error("second endpoint was not stopped after the first failed")
+	}
+}
@@ -0,0 +1,271 @@
+package endpoint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/jk-nd/noumena-mcp-gateway-poc/internal/cassette"
+	"github.com/jk-nd/noumena-mcp-gateway-poc/internal/config"
+	"github.com/jk-nd/noumena-mcp-gateway-poc/internal/rpc"
+)
+
+// recordedEndpoint serves tool calls against a real upstream while
+// capturing traffic into a cassette (mode=record), replays a previously
+// captured cassette without touching the upstream (mode=replay), proxies
+// straight through with no capture (mode=passthrough), or falls back to
+// the plain echo behavior (mode=echo).
+type recordedEndpoint struct {
+	name     string
+	mode     string
+	upstream string
+	strict   bool
+
+	cassettePath string
+	cassette     *cassette.Cassette
+
+	httpClient *http.Client
+	server     *rpc.Server
+	logger     rpc.Logger
+	cancel     context.CancelFunc
+	done       chan struct{}
+	healthy    atomic.Bool
+}
+
+func newRecordedEndpoint(cfg config.Endpoint, httpCfg config.HTTP, logger rpc.Logger) (*recordedEndpoint, error) {
+	e := &recordedEndpoint{
+		name:         cfg.Name,
+		mode:         cfg.Mode,
+		upstream:     cfg.Upstream,
+		strict:       cfg.Strict,
+		cassettePath: cfg.Cassette,
+		logger:       logger,
+		httpClient:   &http.Client{Timeout: httpCfg.WriteTimeout},
+	}
+
+	if e.cassettePath != "" {
+		c, err := cassette.Load(e.cassettePath)
+		if err != nil {
+			return nil, err
+		}
+		e.cassette = c
+		if e.mode == config.ModeRecord {
+			// Append each recording as it happens rather than only writing
+			// the whole cassette on a graceful Stop, so a crash mid-session
+			// loses at most the call in flight.
+			if err := e.cassette.OpenAppend(e.cassettePath); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		e.cassette = cassette.New()
+	}
+
+	e.server = rpc.New(
+		rpc.WithLogger(logger),
+		rpc.WithMiddleware(rpc.Logging(logger)),
+		rpc.WithParallelBatch(cfg.ParallelBatch),
+		rpc.WithTransport(rpc.NewHTTPTransport(cfg.Listen, rpc.WithServerConfig(toServerConfig(httpCfg, cfg.TLS)))),
+	)
+	e.server.Register("tools/call", e.handleToolCall)
+	return e, nil
+}
+
+func (e *recordedEndpoint) Name() string { return e.name }
+
+func (e *recordedEndpoint) Start(ctx context.Context) error {
+	runCtx, cancel := context.WithCancel(ctx)
+	e.cancel = cancel
+	e.done = make(chan struct{})
+
+	go func() {
+		defer close(e.done)
+		defer e.healthy.Store(false)
+		if err := e.server.Run(runCtx); err != nil && runCtx.Err() == nil {
+			e.logger.Printf("endpoint %q: %v", e.name, err)
+		}
+	}()
+
+	awaitHealthy(e.done, &e.healthy)
+	return nil
+}
+
+func (e *recordedEndpoint) Stop(ctx context.Context) error {
+	if e.cancel == nil {
+		return nil
+	}
+	e.cancel()
+	select {
+	case <-e.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if e.cassettePath != "" && e.mode == config.ModeRecord {
+		return e.cassette.Close()
+	}
+	return nil
+}
+
+func (e *recordedEndpoint) Healthy() bool {
+	return e.healthy.Load()
+}
+
+func (e *recordedEndpoint) handleToolCall(ctx context.Context, raw *json.RawMessage) (any, error) {
+	var params toolCallParams
+	if raw != nil {
+		if err := json.Unmarshal(*raw, &params); err != nil {
+			return nil, rpc.NewError(rpc.CodeInvalidParams, "invalid params", err.Error())
+		}
+	}
+	if params.Name == "" {
+		return nil, rpc.NewError(rpc.CodeInvalidParams, "missing tool name", nil)
+	}
+
+	argsRaw, err := json.Marshal(params.Arguments)
+	if err != nil {
+		return nil, rpc.NewError(rpc.CodeInternalError, "encoding arguments", err.Error())
+	}
+
+	switch e.mode {
+	case config.ModeReplay:
+		return e.replay(params.Name, params.Arguments, argsRaw)
+	case config.ModeRecord:
+		return e.record(ctx, params.Name, argsRaw)
+	case config.ModePassthrough:
+		return e.proxy(ctx, params.Name, argsRaw)
+	default:
+		return echoResponse(params.Name, params.Arguments), nil
+	}
+}
+
+func (e *recordedEndpoint) replay(tool string, arguments map[string]interface{}, argsRaw json.RawMessage) (any, error) {
+	key, err := cassette.Key(tool, argsRaw)
+	if err != nil {
+		return nil, rpc.NewError(rpc.CodeInternalError, "hashing params", err.Error())
+	}
+
+	entry, ok := e.cassette.Get(key)
+	if !ok {
+		if e.strict {
+			return nil, rpc.NewError(rpc.CodeMethodNotFound, fmt.Sprintf("no cassette entry for %s", key), nil)
+		}
+		return echoResponse(tool, arguments), nil
+	}
+
+	if entry.DurationMS > 0 {
+		time.Sleep(time.Duration(entry.DurationMS) * time.Millisecond)
+	}
+	return decodeUpstreamResponse(entry.ResponseStatus, entry.ResponseBody)
+}
+
+func (e *recordedEndpoint) record(ctx context.Context, tool string, argsRaw json.RawMessage) (any, error) {
+	start := time.Now()
+	status, body, err := e.callUpstream(ctx, tool, argsRaw)
+	duration := time.Since(start)
+	if err != nil {
+		return nil, rpc.NewError(rpc.CodeInternalError, "upstream call failed", err.Error())
+	}
+
+	key, err := cassette.Key(tool, argsRaw)
+	if err != nil {
+		return nil, rpc.NewError(rpc.CodeInternalError, "hashing params", err.Error())
+	}
+	if err := e.cassette.Put(key, cassette.Entry{
+		Timestamp:      time.Now().UTC().Format(time.RFC3339),
+		Tool:           tool,
+		RequestParams:  argsRaw,
+		ResponseStatus: status,
+		ResponseBody:   body,
+		DurationMS:     duration.Milliseconds(),
+	}); err != nil {
+		e.logger.Printf("endpoint %q: cassette write failed: %v", e.name, err)
+	}
+
+	return decodeUpstreamResponse(status, body)
+}
+
+func (e *recordedEndpoint) proxy(ctx context.Context, tool string, argsRaw json.RawMessage) (any, error) {
+	status, body, err := e.callUpstream(ctx, tool, argsRaw)
+	if err != nil {
+		return nil, rpc.NewError(rpc.CodeInternalError, "upstream call failed", err.Error())
+	}
+	return decodeUpstreamResponse(status, body)
+}
+
+// decodeUpstreamResponse interprets a raw HTTP response from the upstream
+// mock-mcp server, which itself speaks JSON-RPC: a status of 400 or above or
+// a JSON-RPC error both become an rpc.Error, anything else unwraps to the
+// JSON-RPC result.
+func decodeUpstreamResponse(status int, body []byte) (any, error) {
+	if status >= 400 {
+		return nil, rpc.NewError(rpc.CodeInternalError, fmt.Sprintf("upstream status %d", status), json.RawMessage(body))
+	}
+	var envelope rpc.Response
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, rpc.NewError(rpc.CodeInternalError, "decoding upstream response", err.Error())
+	}
+	if envelope.Error != nil {
+		return nil, envelope.Error
+	}
+	return envelope.Result, nil
+}
+
+func (e *recordedEndpoint) callUpstream(ctx context.Context, tool string, argsRaw json.RawMessage) (int, []byte, error) {
+	params, err := json.Marshal(toolCallParamsRaw{Name: tool, Arguments: argsRaw})
+	if err != nil {
+		return 0, nil, err
+	}
+	paramsRaw := json.RawMessage(params)
+	id := json.RawMessage("1")
+
+	payload, err := json.Marshal(rpc.Request{
+		JSONRPC: rpc.Version,
+		Method:  "tools/call",
+		Params:  &paramsRaw,
+		ID:      &id,
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.upstream, bytes.NewReader(payload))
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, body, nil
+}
+
+// toolCallParamsRaw mirrors toolCallParams but carries already-encoded
+// arguments, for forwarding a call upstream without a decode/re-encode
+// round trip.
+type toolCallParamsRaw struct {
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments"`
+}
+
+func echoResponse(tool string, arguments map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"success":   true,
+		"tool":      tool,
+		"timestamp": time.Now().Format(time.RFC3339),
+		"message":   fmt.Sprintf("Mock execution of '%s' completed successfully", tool),
+		"echo":      arguments,
+	}
+}
@@ -0,0 +1,28 @@
+package endpoint
+
+import (
+	"github.com/jk-nd/noumena-mcp-gateway-poc/internal/config"
+	"github.com/jk-nd/noumena-mcp-gateway-poc/internal/rpc"
+)
+
+// toServerConfig translates the gateway's YAML/env HTTP tuning into the
+// rpc package's transport-level ServerConfig, folding in an endpoint's own
+// TLS cert/key if it has one.
+func toServerConfig(httpCfg config.HTTP, tls *config.TLS) rpc.ServerConfig {
+	sc := rpc.ServerConfig{
+		ReadTimeout:       httpCfg.ReadTimeout,
+		ReadHeaderTimeout: httpCfg.ReadHeaderTimeout,
+		WriteTimeout:      httpCfg.WriteTimeout,
+		IdleTimeout:       httpCfg.IdleTimeout,
+		MaxHeaderBytes:    httpCfg.MaxHeaderBytes,
+		ShutdownGrace:     httpCfg.ShutdownGrace,
+		CORSOrigin:        httpCfg.CORSOrigin,
+		CORSMethods:       httpCfg.CORSMethods,
+		CORSHeaders:       httpCfg.CORSHeaders,
+	}
+	if tls != nil {
+		sc.TLSCertFile = tls.CertFile
+		sc.TLSKeyFile = tls.KeyFile
+	}
+	return sc
+}
@@ -0,0 +1,24 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Handler processes the params of a single JSON-RPC method call and returns
+// a result to be marshaled back to the caller, or an error. Returning an
+// *Error lets a handler control the exact code/message/data sent to the
+// client; any other error is reported as CodeInternalError.
+type Handler func(ctx context.Context, params *json.RawMessage) (any, error)
+
+// Middleware wraps a Handler to add cross-cutting behavior (logging, auth,
+// rate-limiting, recording, ...). Middlewares are applied in the order they
+// were supplied to WithMiddleware, so the first one is outermost.
+type Middleware func(Handler) Handler
+
+func chain(h Handler, mws ...Middleware) Handler {
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+	return h
+}
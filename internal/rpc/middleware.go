@@ -0,0 +1,36 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// methodKey is the context key used to make the dispatched method name
+// available to middlewares that don't otherwise see it.
+type methodKey struct{}
+
+// MethodFromContext returns the JSON-RPC method being handled, if the
+// server placed it in ctx (it does, before invoking the handler chain).
+func MethodFromContext(ctx context.Context) (string, bool) {
+	m, ok := ctx.Value(methodKey{}).(string)
+	return m, ok
+}
+
+func withMethod(ctx context.Context, method string) context.Context {
+	return context.WithValue(ctx, methodKey{}, method)
+}
+
+// Logging returns a Middleware that logs each call's method, duration, and
+// whether it errored.
+func Logging(logger Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, params *json.RawMessage) (any, error) {
+			method, _ := MethodFromContext(ctx)
+			start := time.Now()
+			result, err := next(ctx, params)
+			logger.Printf("rpc: method=%s duration=%s err=%v", method, time.Since(start), err)
+			return result, err
+		}
+	}
+}
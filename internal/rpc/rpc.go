@@ -0,0 +1,71 @@
+// Package rpc implements a minimal JSON-RPC 2.0 server with pluggable
+// transports and a composable middleware chain, so MCP-style tool calls can
+// be served over HTTP, stdio, or raw TCP without duplicating dispatch logic.
+package rpc
+
+import "encoding/json"
+
+// Standard JSON-RPC 2.0 error codes, as defined by the spec.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+)
+
+// Version is the only JSON-RPC protocol version this server understands.
+const Version = "2.0"
+
+// Request is a single JSON-RPC 2.0 request object. Notifications (no "id")
+// are accepted but their responses are discarded by the dispatcher.
+type Request struct {
+	JSONRPC string           `json:"jsonrpc"`
+	Method  string           `json:"method"`
+	Params  *json.RawMessage `json:"params,omitempty"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+}
+
+// Response is a single JSON-RPC 2.0 response object. Result and Error are
+// mutually exclusive, matching the spec.
+type Response struct {
+	JSONRPC string           `json:"jsonrpc"`
+	Result  any              `json:"result,omitempty"`
+	Error   *Error           `json:"error,omitempty"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+}
+
+// Error is a JSON-RPC 2.0 error object.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// NewError wraps a code/message pair into an *Error, suitable for returning
+// from a Handler.
+func NewError(code int, message string, data any) *Error {
+	return &Error{Code: code, Message: message, Data: data}
+}
+
+func newResponse(id *json.RawMessage, result any, err error) Response {
+	resp := Response{JSONRPC: Version, ID: id}
+	if err != nil {
+		if rpcErr, ok := err.(*Error); ok {
+			resp.Error = rpcErr
+		} else {
+			resp.Error = NewError(CodeInternalError, err.Error(), nil)
+		}
+		return resp
+	}
+	resp.Result = result
+	return resp
+}
+
+func isNotification(req Request) bool {
+	return req.ID == nil
+}
@@ -0,0 +1,229 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+)
+
+// Logger is the minimal logging surface the server needs. *log.Logger
+// satisfies it, so callers can pass log.Default() or a custom logger
+// without pulling in a logging dependency.
+type Logger interface {
+	Printf(format string, v ...any)
+}
+
+// Option configures a Server. Options are applied in order by New.
+type Option func(*Server)
+
+// WithTransport registers one or more transports the server should serve
+// requests on when Run is called. HTTP, stdio, and TCP transports are
+// provided by this package; callers may supply their own by implementing
+// Transport.
+func WithTransport(transports ...Transport) Option {
+	return func(s *Server) {
+		s.transports = append(s.transports, transports...)
+	}
+}
+
+// WithMiddleware appends middlewares to the chain wrapped around every
+// registered handler, applied in the order given (first = outermost).
+func WithMiddleware(mws ...Middleware) Option {
+	return func(s *Server) {
+		s.middlewares = append(s.middlewares, mws...)
+	}
+}
+
+// WithLogger sets the logger used for transport lifecycle and dispatch
+// errors. Defaults to log.Default().
+func WithLogger(l Logger) Option {
+	return func(s *Server) {
+		s.logger = l
+	}
+}
+
+// WithParallelBatch controls whether the requests inside a JSON-RPC batch
+// are dispatched concurrently. Defaults to false (sequential, in order).
+func WithParallelBatch(parallel bool) Option {
+	return func(s *Server) {
+		s.parallel = parallel
+	}
+}
+
+// Server dispatches JSON-RPC 2.0 requests to registered Handlers over one
+// or more Transports.
+type Server struct {
+	mu          sync.RWMutex
+	handlers    map[string]Handler
+	middlewares []Middleware
+	transports  []Transport
+	logger      Logger
+	parallel    bool
+}
+
+// New builds a Server from the given options. It has no handlers and no
+// transports registered until Register and WithTransport add them.
+func New(opts ...Option) *Server {
+	s := &Server{
+		handlers: make(map[string]Handler),
+		logger:   log.Default(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Register adds a handler for method, wrapping it in the server's
+// middleware chain. Registering the same method twice replaces the
+// previous handler.
+func (s *Server) Register(method string, handler Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[method] = chain(handler, s.middlewares...)
+}
+
+// Run starts every registered transport and blocks until ctx is canceled or
+// a transport returns an error. All transports are stopped when Run returns.
+func (s *Server) Run(ctx context.Context) error {
+	if len(s.transports) == 0 {
+		return fmt.Errorf("rpc: no transports registered")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errCh := make(chan error, len(s.transports))
+	var wg sync.WaitGroup
+	for _, t := range s.transports {
+		wg.Add(1)
+		go func(t Transport) {
+			defer wg.Done()
+			s.logger.Printf("rpc: starting transport %q", t.Name())
+			if err := t.Serve(ctx, s.HandleRaw); err != nil && ctx.Err() == nil {
+				errCh <- fmt.Errorf("transport %q: %w", t.Name(), err)
+				cancel()
+			}
+		}(t)
+	}
+
+	wg.Wait()
+	close(errCh)
+	return <-errCh
+}
+
+// HandleRaw parses raw as either a single JSON-RPC request object or a
+// batch array, dispatches each to its handler, and marshals the response(s)
+// back to JSON. It returns nil if raw contained only notifications (no
+// response is owed to the caller).
+func (s *Server) HandleRaw(ctx context.Context, raw []byte) []byte {
+	trimmed := firstNonSpace(raw)
+	if trimmed == '[' {
+		return s.handleBatch(ctx, raw)
+	}
+	return s.handleSingle(ctx, raw)
+}
+
+func firstNonSpace(raw []byte) byte {
+	for _, b := range raw {
+		switch b {
+		case ' ', '\t', '\n', '\r':
+			continue
+		default:
+			return b
+		}
+	}
+	return 0
+}
+
+func (s *Server) handleSingle(ctx context.Context, raw []byte) []byte {
+	var req Request
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return mustMarshal(newResponse(nil, nil, NewError(CodeParseError, "parse error", err.Error())))
+	}
+
+	resp := s.dispatch(ctx, req)
+	if isNotification(req) {
+		return nil
+	}
+	return mustMarshal(resp)
+}
+
+func (s *Server) handleBatch(ctx context.Context, raw []byte) []byte {
+	var reqs []Request
+	if err := json.Unmarshal(raw, &reqs); err != nil {
+		return mustMarshal(newResponse(nil, nil, NewError(CodeParseError, "parse error", err.Error())))
+	}
+	if len(reqs) == 0 {
+		return mustMarshal(newResponse(nil, nil, NewError(CodeInvalidRequest, "empty batch", nil)))
+	}
+
+	resps := make([]Response, len(reqs))
+	keep := make([]bool, len(reqs))
+
+	dispatchOne := func(i int) {
+		resps[i] = s.dispatch(ctx, reqs[i])
+		keep[i] = !isNotification(reqs[i])
+	}
+
+	if s.parallel {
+		var wg sync.WaitGroup
+		for i := range reqs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				dispatchOne(i)
+			}(i)
+		}
+		wg.Wait()
+	} else {
+		for i := range reqs {
+			dispatchOne(i)
+		}
+	}
+
+	out := make([]Response, 0, len(resps))
+	for i, r := range resps {
+		if keep[i] {
+			out = append(out, r)
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return mustMarshal(out)
+}
+
+func (s *Server) dispatch(ctx context.Context, req Request) Response {
+	if req.JSONRPC != Version {
+		return newResponse(req.ID, nil, NewError(CodeInvalidRequest, "invalid jsonrpc version", req.JSONRPC))
+	}
+	if req.Method == "" {
+		return newResponse(req.ID, nil, NewError(CodeInvalidRequest, "missing method", nil))
+	}
+
+	s.mu.RLock()
+	handler, ok := s.handlers[req.Method]
+	s.mu.RUnlock()
+	if !ok {
+		return newResponse(req.ID, nil, NewError(CodeMethodNotFound, fmt.Sprintf("method not found: %s", req.Method), nil))
+	}
+
+	result, err := handler(withMethod(ctx, req.Method), req.Params)
+	if err != nil {
+		s.logger.Printf("rpc: method %q returned error: %v", req.Method, err)
+	}
+	return newResponse(req.ID, result, err)
+}
+
+func mustMarshal(v any) []byte {
+	b, err := json.Marshal(v)
+	if err != nil {
+		// Only reachable if a Handler's result isn't JSON-marshalable, which
+		// is a programming error in the handler, not a runtime condition.
+		panic(fmt.Sprintf("rpc: failed to marshal response: %v", err))
+	}
+	return b
+}
@@ -0,0 +1,190 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestServer(opts ...Option) *Server {
+	opts = append([]Option{WithLogger(log.New(discard{}, "", 0))}, opts...)
+	return New(opts...)
+}
+
+// discard is an io.Writer that throws away everything written to it, so
+// tests don't spam stdout with the server's dispatch logging.
+type discard struct{}
+
+func (discard) Write(p []byte) (int, error) { return len(p), nil }
+
+func TestHandleRawSingleSuccess(t *testing.T) {
+	s := newTestServer()
+	s.Register("echo", func(ctx context.Context, params *json.RawMessage) (any, error) {
+		return map[string]string{"ok": "yes"}, nil
+	})
+
+	out := s.HandleRaw(context.Background(), []byte(`{"jsonrpc":"2.0","method":"echo","id":1}`))
+
+	var resp Response
+	if err := json.Unmarshal(out, &resp); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %+v", resp.Error)
+	}
+	if resp.Result == nil {
+		t.Fatal("expected a result, got none")
+	}
+}
+
+func TestHandleRawSingleHandlerError(t *testing.T) {
+	s := newTestServer()
+	s.Register("boom", func(ctx context.Context, params *json.RawMessage) (any, error) {
+		return nil, NewError(CodeInvalidParams, "bad params", nil)
+	})
+
+	out := s.HandleRaw(context.Background(), []byte(`{"jsonrpc":"2.0","method":"boom","id":1}`))
+
+	var resp Response
+	if err := json.Unmarshal(out, &resp); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if resp.Error == nil || resp.Error.Code != CodeInvalidParams {
+		t.Fatalf("resp.Error = %+v, want code %d", resp.Error, CodeInvalidParams)
+	}
+}
+
+func TestHandleRawUnknownMethod(t *testing.T) {
+	s := newTestServer()
+
+	out := s.HandleRaw(context.Background(), []byte(`{"jsonrpc":"2.0","method":"nope","id":1}`))
+
+	var resp Response
+	if err := json.Unmarshal(out, &resp); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if resp.Error == nil || resp.Error.Code != CodeMethodNotFound {
+		t.Fatalf("resp.Error = %+v, want code %d", resp.Error, CodeMethodNotFound)
+	}
+}
+
+func TestHandleRawParseError(t *testing.T) {
+	s := newTestServer()
+
+	out := s.HandleRaw(context.Background(), []byte(`{not json`))
+
+	var resp Response
+	if err := json.Unmarshal(out, &resp); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if resp.Error == nil || resp.Error.Code != CodeParseError {
+		t.Fatalf("resp.Error = %+v, want code %d", resp.Error, CodeParseError)
+	}
+}
+
+func TestHandleRawNotificationHasNoResponse(t *testing.T) {
+	s := newTestServer()
+	var called atomic.Bool
+	s.Register("ping", func(ctx context.Context, params *json.RawMessage) (any, error) {
+		called.Store(true)
+		return "pong", nil
+	})
+
+	out := s.HandleRaw(context.Background(), []byte(`{"jsonrpc":"2.0","method":"ping"}`))
+
+	if out != nil {
+		t.Fatalf("HandleRaw for a notification = %q, want nil", out)
+	}
+	if !called.Load() {
+		t.Error("notification handler was never invoked")
+	}
+}
+
+func TestHandleRawBatchDropsNotifications(t *testing.T) {
+	s := newTestServer()
+	s.Register("echo", func(ctx context.Context, params *json.RawMessage) (any, error) {
+		return "ok", nil
+	})
+
+	batch := `[
+		{"jsonrpc":"2.0","method":"echo","id":1},
+		{"jsonrpc":"2.0","method":"echo"},
+		{"jsonrpc":"2.0","method":"echo","id":2}
+	]`
+	out := s.HandleRaw(context.Background(), []byte(batch))
+
+	var resps []Response
+	if err := json.Unmarshal(out, &resps); err != nil {
+		t.Fatalf("unmarshal batch response: %v", err)
+	}
+	if len(resps) != 2 {
+		t.Fatalf("batch response has %d entries, want 2 (notification dropped)", len(resps))
+	}
+}
+
+func TestHandleRawEmptyBatchIsInvalidRequest(t *testing.T) {
+	s := newTestServer()
+
+	out := s.HandleRaw(context.Background(), []byte(`[]`))
+
+	var resp Response
+	if err := json.Unmarshal(out, &resp); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if resp.Error == nil || resp.Error.Code != CodeInvalidRequest {
+		t.Fatalf("resp.Error = %+v, want code %d", resp.Error, CodeInvalidRequest)
+	}
+}
+
+func TestHandleRawBatchAllNotificationsHasNoResponse(t *testing.T) {
+	s := newTestServer()
+	s.Register("echo", func(ctx context.Context, params *json.RawMessage) (any, error) {
+		return "ok", nil
+	})
+
+	out := s.HandleRaw(context.Background(), []byte(`[{"jsonrpc":"2.0","method":"echo"}]`))
+
+	if out != nil {
+		t.Fatalf("HandleRaw for an all-notification batch = %q, want nil", out)
+	}
+}
+
+func TestWithParallelBatchRunsHandlersConcurrently(t *testing.T) {
+	const n = 4
+	const delay = 50 * time.Millisecond
+
+	s := newTestServer(WithParallelBatch(true))
+	s.Register("slow", func(ctx context.Context, params *json.RawMessage) (any, error) {
+		time.Sleep(delay)
+		return "ok", nil
+	})
+
+	reqs := make([]map[string]any, n)
+	for i := range reqs {
+		reqs[i] = map[string]any{"jsonrpc": "2.0", "method": "slow", "id": i + 1}
+	}
+	batch, err := json.Marshal(reqs)
+	if err != nil {
+		t.Fatalf("marshal batch: %v", err)
+	}
+
+	start := time.Now()
+	out := s.HandleRaw(context.Background(), batch)
+	elapsed := time.Since(start)
+
+	var resps []Response
+	if err := json.Unmarshal(out, &resps); err != nil {
+		t.Fatalf("unmarshal batch response: %v", err)
+	}
+	if len(resps) != n {
+		t.Fatalf("got %d responses, want %d", len(resps), n)
+	}
+	// Sequential dispatch would take at least n*delay; concurrent dispatch
+	// should finish well under that, close to one delay's worth.
+	if elapsed >= n*delay {
+		t.Errorf("batch took %s, want well under %s (handlers should run concurrently)", elapsed, n*delay)
+	}
+}
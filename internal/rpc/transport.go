@@ -0,0 +1,16 @@
+package rpc
+
+import "context"
+
+// Dispatch parses a raw JSON-RPC payload (single request or batch) and
+// returns the raw JSON response payload to send back, or nil if nothing is
+// owed to the caller (a notification or an all-notification batch).
+type Dispatch func(ctx context.Context, raw []byte) []byte
+
+// Transport serves JSON-RPC requests from some source (HTTP, stdio, TCP, ...)
+// and hands each raw payload to dispatch. Serve must block until ctx is
+// canceled or an unrecoverable error occurs.
+type Transport interface {
+	Name() string
+	Serve(ctx context.Context, dispatch Dispatch) error
+}
@@ -0,0 +1,207 @@
+package rpc
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// HTTPOption configures an HTTPTransport.
+type HTTPOption func(*HTTPTransport)
+
+// WithPath sets the path the transport accepts JSON-RPC POSTs on. Defaults
+// to "/".
+func WithPath(path string) HTTPOption {
+	return func(t *HTTPTransport) { t.path = path }
+}
+
+// WithExtraHandler mounts an additional handler on the transport's mux
+// alongside the JSON-RPC path, for admin/debug endpoints that live on the
+// same listener (e.g. scenario injection).
+func WithExtraHandler(pattern string, handler http.Handler) HTTPOption {
+	return func(t *HTTPTransport) {
+		t.extra = append(t.extra, extraRoute{pattern: pattern, handler: handler})
+	}
+}
+
+// WithServerConfig replaces the transport's http.Server tuning (timeouts,
+// header cap, TLS, CORS, shutdown grace) with cfg.
+func WithServerConfig(cfg ServerConfig) HTTPOption {
+	return func(t *HTTPTransport) { t.cfg = cfg }
+}
+
+type extraRoute struct {
+	pattern string
+	handler http.Handler
+}
+
+// ServerConfig tunes the *http.Server an HTTPTransport builds. The zero
+// value falls back to DefaultServerConfig.
+type ServerConfig struct {
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	MaxHeaderBytes    int
+	ShutdownGrace     time.Duration
+
+	TLSCertFile string
+	TLSKeyFile  string
+
+	CORSOrigin  string
+	CORSMethods string
+	CORSHeaders string
+}
+
+// DefaultServerConfig mirrors a typical production proxy's http block:
+// generous but bounded timeouts, no TLS, no CORS.
+func DefaultServerConfig() ServerConfig {
+	return ServerConfig{
+		ReadTimeout:       30 * time.Second,
+		ReadHeaderTimeout: 10 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		IdleTimeout:       120 * time.Second,
+		MaxHeaderBytes:    1 << 20,
+		ShutdownGrace:     10 * time.Second,
+	}
+}
+
+func (c ServerConfig) withDefaults() ServerConfig {
+	def := DefaultServerConfig()
+	if c.ReadTimeout == 0 {
+		c.ReadTimeout = def.ReadTimeout
+	}
+	if c.ReadHeaderTimeout == 0 {
+		c.ReadHeaderTimeout = def.ReadHeaderTimeout
+	}
+	if c.WriteTimeout == 0 {
+		c.WriteTimeout = def.WriteTimeout
+	}
+	if c.IdleTimeout == 0 {
+		c.IdleTimeout = def.IdleTimeout
+	}
+	if c.MaxHeaderBytes == 0 {
+		c.MaxHeaderBytes = def.MaxHeaderBytes
+	}
+	if c.ShutdownGrace == 0 {
+		c.ShutdownGrace = def.ShutdownGrace
+	}
+	return c
+}
+
+func (c ServerConfig) tlsEnabled() bool {
+	return c.TLSCertFile != "" && c.TLSKeyFile != ""
+}
+
+func (c ServerConfig) corsEnabled() bool {
+	return c.CORSOrigin != ""
+}
+
+// corsMiddleware sets CORS headers per cfg and short-circuits preflight
+// OPTIONS requests.
+func corsMiddleware(cfg ServerConfig, next http.Handler) http.Handler {
+	methods := cfg.CORSMethods
+	if methods == "" {
+		methods = "GET, POST, OPTIONS"
+	}
+	headers := cfg.CORSHeaders
+	if headers == "" {
+		headers = "Content-Type"
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", cfg.CORSOrigin)
+		w.Header().Set("Access-Control-Allow-Methods", methods)
+		w.Header().Set("Access-Control-Allow-Headers", headers)
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// HTTPTransport serves JSON-RPC requests as HTTP POST bodies on a single
+// path, the conventional way to carry JSON-RPC 2.0 over HTTP.
+type HTTPTransport struct {
+	addr   string
+	path   string
+	extra  []extraRoute
+	cfg    ServerConfig
+	server *http.Server
+}
+
+// NewHTTPTransport builds a transport that listens on addr (":8080", etc).
+func NewHTTPTransport(addr string, opts ...HTTPOption) *HTTPTransport {
+	t := &HTTPTransport{addr: addr, path: "/", cfg: DefaultServerConfig()}
+	for _, opt := range opts {
+		opt(t)
+	}
+	t.cfg = t.cfg.withDefaults()
+	return t
+}
+
+func (t *HTTPTransport) Name() string { return "http" }
+
+func (t *HTTPTransport) Serve(ctx context.Context, dispatch Dispatch) error {
+	mux := http.NewServeMux()
+	for _, route := range t.extra {
+		mux.Handle(route.pattern, route.handler)
+	}
+	mux.HandleFunc(t.path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+
+		resp := dispatch(r.Context(), body)
+		w.Header().Set("Content-Type", "application/json")
+		if resp == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Write(resp)
+	})
+
+	var handler http.Handler = mux
+	if t.cfg.corsEnabled() {
+		handler = corsMiddleware(t.cfg, mux)
+	}
+
+	t.server = &http.Server{
+		Addr:              t.addr,
+		Handler:           handler,
+		ReadTimeout:       t.cfg.ReadTimeout,
+		ReadHeaderTimeout: t.cfg.ReadHeaderTimeout,
+		WriteTimeout:      t.cfg.WriteTimeout,
+		IdleTimeout:       t.cfg.IdleTimeout,
+		MaxHeaderBytes:    t.cfg.MaxHeaderBytes,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		if t.cfg.tlsEnabled() {
+			errCh <- t.server.ListenAndServeTLS(t.cfg.TLSCertFile, t.cfg.TLSKeyFile)
+		} else {
+			errCh <- t.server.ListenAndServe()
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), t.cfg.ShutdownGrace)
+		defer cancel()
+		return t.server.Shutdown(shutdownCtx)
+	case err := <-errCh:
+		if err == http.ErrServerClosed {
+			return nil
+		}
+		return err
+	}
+}
@@ -0,0 +1,66 @@
+package rpc
+
+import (
+	"bufio"
+	"context"
+	"io"
+)
+
+// StdioTransport serves JSON-RPC requests as newline-delimited JSON read
+// from an input stream, writing each response as a newline-delimited line
+// to an output stream. This is the transport MCP clients typically use when
+// they spawn the server as a subprocess.
+type StdioTransport struct {
+	in  io.Reader
+	out io.Writer
+}
+
+// NewStdioTransport builds a transport reading requests from in and writing
+// responses to out (usually os.Stdin / os.Stdout).
+func NewStdioTransport(in io.Reader, out io.Writer) *StdioTransport {
+	return &StdioTransport{in: in, out: out}
+}
+
+func (t *StdioTransport) Name() string { return "stdio" }
+
+func (t *StdioTransport) Serve(ctx context.Context, dispatch Dispatch) error {
+	lines := make(chan []byte)
+	errCh := make(chan error, 1)
+
+	go func() {
+		scanner := bufio.NewScanner(t.in)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			line := append([]byte(nil), scanner.Bytes()...)
+			if len(line) == 0 {
+				continue
+			}
+			lines <- line
+		}
+		if err := scanner.Err(); err != nil {
+			errCh <- err
+			return
+		}
+		errCh <- io.EOF
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errCh:
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		case line := <-lines:
+			resp := dispatch(ctx, line)
+			if resp == nil {
+				continue
+			}
+			if _, err := t.out.Write(append(resp, '\n')); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package rpc
+
+import (
+	"bufio"
+	"context"
+	"net"
+)
+
+// TCPTransport serves JSON-RPC requests over raw TCP connections, one
+// newline-delimited request per line, mirroring StdioTransport's framing so
+// the same clients can be pointed at a socket instead of a subprocess.
+type TCPTransport struct {
+	addr     string
+	listener net.Listener
+}
+
+// NewTCPTransport builds a transport that listens on addr (":9090", etc).
+func NewTCPTransport(addr string) *TCPTransport {
+	return &TCPTransport{addr: addr}
+}
+
+func (t *TCPTransport) Name() string { return "tcp" }
+
+func (t *TCPTransport) Serve(ctx context.Context, dispatch Dispatch) error {
+	ln, err := net.Listen("tcp", t.addr)
+	if err != nil {
+		return err
+	}
+	t.listener = ln
+
+	go func() {
+		<-ctx.Done()
+		ln.Close()
+	}()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		go t.serveConn(ctx, conn, dispatch)
+	}
+}
+
+func (t *TCPTransport) serveConn(ctx context.Context, conn net.Conn, dispatch Dispatch) {
+	defer conn.Close()
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		resp := dispatch(ctx, line)
+		if resp == nil {
+			continue
+		}
+		resp = append(resp, '\n')
+		if _, err := conn.Write(resp); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package scenarios
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// AdminHandler serves POST /_mock/scenarios: the body is either a single
+// Scenario object or a JSON array of them, and each is registered on store
+// immediately, letting a test inject fixtures into a running mock process.
+func AdminHandler(store *Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		added, err := decodeScenarios(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		store.Add(added...)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"added": len(added)})
+	}
+}
+
+func decodeScenarios(r *http.Request) ([]*Scenario, error) {
+	defer r.Body.Close()
+	dec := json.NewDecoder(r.Body)
+
+	var raw json.RawMessage
+	if err := dec.Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	if len(raw) > 0 && raw[0] == '[' {
+		var list []*Scenario
+		if err := json.Unmarshal(raw, &list); err != nil {
+			return nil, err
+		}
+		return list, nil
+	}
+
+	var one Scenario
+	if err := json.Unmarshal(raw, &one); err != nil {
+		return nil, err
+	}
+	return []*Scenario{&one}, nil
+}
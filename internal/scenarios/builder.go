@@ -0,0 +1,60 @@
+package scenarios
+
+// Builder provides the in-process fluent API for registering scenarios
+// from Go tests:
+//
+//	store := scenarios.New()
+//	store.On("search").WithParams(map[string]scenarios.ParamPredicate{
+//		"query": {Contains: "foo"},
+//	}).Reply(scenarios.Response{Body: map[string]any{"ok": true}})
+type Builder struct {
+	store *Store
+}
+
+// New builds a Builder backed by a fresh, empty Store.
+func New() *Builder {
+	return &Builder{store: NewStore()}
+}
+
+// Store returns the underlying Store, e.g. to wire it into an endpoint or
+// call AssertConsumed.
+func (b *Builder) Store() *Store {
+	return b.store
+}
+
+// On starts building a scenario matching calls to the named tool ("*" or
+// "" for any tool).
+func (b *Builder) On(tool string) *ScenarioBuilder {
+	return &ScenarioBuilder{
+		store:    b.store,
+		scenario: &Scenario{Match: Match{Tool: tool}},
+	}
+}
+
+// ScenarioBuilder accumulates match criteria for a single scenario before
+// Reply registers it.
+type ScenarioBuilder struct {
+	store    *Store
+	scenario *Scenario
+}
+
+// WithParams sets the per-param predicates the scenario must match.
+func (sb *ScenarioBuilder) WithParams(params map[string]ParamPredicate) *ScenarioBuilder {
+	sb.scenario.Match.Params = params
+	return sb
+}
+
+// Times limits how many times the scenario fires before falling through.
+// Times(1) makes it one-shot. The default (unset) is unlimited.
+func (sb *ScenarioBuilder) Times(n int) *ScenarioBuilder {
+	sb.scenario.Times = n
+	return sb
+}
+
+// Reply finalizes the scenario with resp, registers it on the store, and
+// returns it so a test can inspect how many times it fired.
+func (sb *ScenarioBuilder) Reply(resp Response) *Scenario {
+	sb.scenario.Respond = resp
+	sb.store.Add(sb.scenario)
+	return sb.scenario
+}
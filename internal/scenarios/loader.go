@@ -0,0 +1,49 @@
+package scenarios
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fixtureFile is the shape of a scenarios fixture file: an object whose
+// "scenarios" key holds the list of scenarios.
+type fixtureFile struct {
+	Scenarios []*Scenario `yaml:"scenarios" json:"scenarios"`
+}
+
+// LoadFile parses a scenarios fixture from path. YAML (.yml/.yaml) and JSON
+// (.json) are both supported, chosen by file extension.
+func LoadFile(path string) ([]*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("scenarios: read %s: %w", path, err)
+	}
+
+	var file fixtureFile
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("scenarios: parse %s: %w", path, err)
+		}
+	default:
+		if err := yaml.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("scenarios: parse %s: %w", path, err)
+		}
+	}
+	return file.Scenarios, nil
+}
+
+// LoadFileInto loads the fixture at path and adds every scenario to store.
+func LoadFileInto(store *Store, path string) error {
+	loaded, err := LoadFile(path)
+	if err != nil {
+		return err
+	}
+	store.Add(loaded...)
+	return nil
+}
@@ -0,0 +1,168 @@
+// Package scenarios provides a declarative fixture engine for the mock
+// endpoints: instead of always echoing a tool call back, a Scenario can
+// match on tool name and param predicates and reply with a canned
+// response, a delay, and a limited repeat count.
+package scenarios
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Match describes which tool calls a Scenario applies to. An empty or "*"
+// Tool is a catch-all that matches any tool name. A nil/empty Params map
+// matches any params.
+type Match struct {
+	Tool   string                    `yaml:"tool" json:"tool"`
+	Params map[string]ParamPredicate `yaml:"params,omitempty" json:"params,omitempty"`
+}
+
+// ParamPredicate matches a single param value. Exactly one field should be
+// set; Exact wins if more than one is.
+type ParamPredicate struct {
+	Exact          interface{}     `yaml:"exact,omitempty" json:"exact,omitempty"`
+	Contains       string          `yaml:"contains,omitempty" json:"contains,omitempty"`
+	Regex          string          `yaml:"regex,omitempty" json:"regex,omitempty"`
+	JSONPathEquals *JSONPathEquals `yaml:"jsonpath_equals,omitempty" json:"jsonpath_equals,omitempty"`
+}
+
+// JSONPathEquals matches a dotted path inside a param value against an
+// expected value. It's intentionally a small subset of real JSONPath: dots
+// walk nested map keys.
+type JSONPathEquals struct {
+	Path  string      `yaml:"path" json:"path"`
+	Value interface{} `yaml:"value" json:"value"`
+}
+
+// Match reports whether value satisfies this predicate.
+func (p ParamPredicate) Match(value interface{}) bool {
+	switch {
+	case p.Exact != nil:
+		return jsonEqual(value, p.Exact)
+	case p.Contains != "":
+		return strings.Contains(fmt.Sprintf("%v", value), p.Contains)
+	case p.Regex != "":
+		re, err := regexp.Compile(p.Regex)
+		if err != nil {
+			return false
+		}
+		return re.MatchString(fmt.Sprintf("%v", value))
+	case p.JSONPathEquals != nil:
+		resolved, ok := resolvePath(value, p.JSONPathEquals.Path)
+		return ok && jsonEqual(resolved, p.JSONPathEquals.Value)
+	default:
+		// No predicate set matches anything, same as an absent key in Params.
+		return true
+	}
+}
+
+func jsonEqual(a, b interface{}) bool {
+	aj, errA := json.Marshal(a)
+	bj, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return string(aj) == string(bj)
+}
+
+// resolvePath walks a dotted path ("user.id") through nested maps.
+func resolvePath(value interface{}, path string) (interface{}, bool) {
+	if path == "" {
+		return value, true
+	}
+	cur := value
+	for _, key := range strings.Split(path, ".") {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		cur, ok = m[key]
+		if !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// matchParams reports whether params satisfies every predicate in m.Params.
+// Predicates for param names absent from params never match.
+func (m Match) matchParams(params map[string]interface{}) bool {
+	for name, predicate := range m.Params {
+		value, ok := params[name]
+		if !ok || !predicate.Match(value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m Match) matchTool(tool string) bool {
+	return m.Tool == "" || m.Tool == "*" || m.Tool == tool
+}
+
+// Response is the canned reply a matched Scenario produces. Status and
+// Headers only take effect on transports that expose raw HTTP responses;
+// the JSON-RPC tool-call handler folds Status/Body into an RPC result or
+// error and ignores Headers.
+type Response struct {
+	Status  int               `yaml:"status,omitempty" json:"status,omitempty"`
+	Headers map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+	Body    interface{}       `yaml:"body,omitempty" json:"body,omitempty"`
+	DelayMS int               `yaml:"delay_ms,omitempty" json:"delay_ms,omitempty"`
+}
+
+// Scenario is a single declarative fixture: a Match predicate, the
+// Response to reply with, and how many times it should fire before falling
+// through (0 means unlimited).
+type Scenario struct {
+	Match   Match    `yaml:"match" json:"match"`
+	Respond Response `yaml:"respond" json:"respond"`
+	Times   int      `yaml:"times,omitempty" json:"times,omitempty"`
+
+	mu        sync.Mutex
+	remaining int
+	fired     int
+	armed     bool
+}
+
+// arm initializes the scenario's remaining-count bookkeeping. It's called
+// once by Store.Add so Scenarios built directly (e.g. via yaml.Unmarshal)
+// behave the same as ones built through the fluent Builder API.
+func (s *Scenario) arm() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.armed {
+		return
+	}
+	s.armed = true
+	s.remaining = s.Times
+}
+
+// tryFire reports whether the scenario matches (tool, params) and, if so,
+// consumes one use and returns its Response.
+func (s *Scenario) tryFire(tool string, params map[string]interface{}) (Response, bool) {
+	if !s.Match.matchTool(tool) || !s.Match.matchParams(params) {
+		return Response{}, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Times > 0 && s.remaining <= 0 {
+		return Response{}, false
+	}
+	if s.Times > 0 {
+		s.remaining--
+	}
+	s.fired++
+	return s.Respond, true
+}
+
+// Fired reports how many times this scenario has matched and replied.
+func (s *Scenario) Fired() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fired
+}
@@ -0,0 +1,176 @@
+package scenarios
+
+import "testing"
+
+type fakeT struct {
+	errors []string
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...interface{}) {
+	f.errors = append(f.errors, format)
+}
+
+func TestParamPredicateExact(t *testing.T) {
+	p := ParamPredicate{Exact: "hello"}
+	if !p.Match("hello") {
+		t.Error("Exact should match identical value")
+	}
+	if p.Match("world") {
+		t.Error("Exact should not match a different value")
+	}
+}
+
+func TestParamPredicateContains(t *testing.T) {
+	p := ParamPredicate{Contains: "ell"}
+	if !p.Match("hello") {
+		t.Error("Contains should match a substring")
+	}
+	if p.Match("world") {
+		t.Error("Contains should not match when absent")
+	}
+}
+
+func TestParamPredicateRegex(t *testing.T) {
+	p := ParamPredicate{Regex: `^\d+$`}
+	if !p.Match("12345") {
+		t.Error("Regex should match all-digit string")
+	}
+	if p.Match("12a45") {
+		t.Error("Regex should not match a string with a letter")
+	}
+}
+
+func TestParamPredicateRegexInvalidNeverMatches(t *testing.T) {
+	p := ParamPredicate{Regex: `(`}
+	if p.Match("anything") {
+		t.Error("an unparseable regex should never match")
+	}
+}
+
+func TestParamPredicateJSONPathEquals(t *testing.T) {
+	p := ParamPredicate{JSONPathEquals: &JSONPathEquals{Path: "user.id", Value: float64(42)}}
+	value := map[string]interface{}{
+		"user": map[string]interface{}{"id": float64(42)},
+	}
+	if !p.Match(value) {
+		t.Error("JSONPathEquals should match the resolved nested value")
+	}
+
+	miss := map[string]interface{}{
+		"user": map[string]interface{}{"id": float64(7)},
+	}
+	if p.Match(miss) {
+		t.Error("JSONPathEquals should not match a different resolved value")
+	}
+
+	missing := map[string]interface{}{"user": map[string]interface{}{}}
+	if p.Match(missing) {
+		t.Error("JSONPathEquals should not match when the path doesn't resolve")
+	}
+}
+
+func TestParamPredicateEmptyMatchesAnything(t *testing.T) {
+	p := ParamPredicate{}
+	if !p.Match("whatever") {
+		t.Error("an empty predicate should match any value")
+	}
+}
+
+func TestScenarioTimesLimitsFiring(t *testing.T) {
+	s := &Scenario{
+		Match:   Match{Tool: "once"},
+		Respond: Response{Status: 200},
+		Times:   1,
+	}
+	s.arm()
+
+	if _, ok := s.tryFire("once", nil); !ok {
+		t.Fatal("first call should fire")
+	}
+	if _, ok := s.tryFire("once", nil); ok {
+		t.Fatal("second call should fall through once Times is exhausted")
+	}
+	if s.Fired() != 1 {
+		t.Errorf("Fired() = %d, want 1", s.Fired())
+	}
+}
+
+func TestScenarioUnlimitedFiresRepeatedly(t *testing.T) {
+	s := &Scenario{Match: Match{Tool: "unlimited"}, Respond: Response{Status: 200}}
+	s.arm()
+
+	for i := 0; i < 3; i++ {
+		if _, ok := s.tryFire("unlimited", nil); !ok {
+			t.Fatalf("call %d should fire, Times=0 means unlimited", i)
+		}
+	}
+}
+
+func TestScenarioMatchToolAndParams(t *testing.T) {
+	s := &Scenario{
+		Match: Match{
+			Tool:   "greet",
+			Params: map[string]ParamPredicate{"name": {Exact: "ada"}},
+		},
+		Respond: Response{Status: 200},
+	}
+	s.arm()
+
+	if _, ok := s.tryFire("greet", map[string]interface{}{"name": "ada"}); !ok {
+		t.Error("should fire when tool and params match")
+	}
+	if _, ok := s.tryFire("greet", map[string]interface{}{"name": "grace"}); ok {
+		t.Error("should not fire when a param predicate fails")
+	}
+	if _, ok := s.tryFire("other", map[string]interface{}{"name": "ada"}); ok {
+		t.Error("should not fire for a different tool")
+	}
+}
+
+func TestStoreMatchFirstMatchWins(t *testing.T) {
+	store := NewStore()
+	first := &Scenario{Match: Match{Tool: "dup"}, Respond: Response{Status: 200}}
+	second := &Scenario{Match: Match{Tool: "dup"}, Respond: Response{Status: 500}}
+	store.Add(first, second)
+
+	resp, ok := store.Match("dup", nil)
+	if !ok {
+		t.Fatal("expected a match")
+	}
+	if resp.Status != 200 {
+		t.Errorf("resp.Status = %d, want 200 (first-registered scenario should win)", resp.Status)
+	}
+	if second.Fired() != 0 {
+		t.Error("second scenario should not have fired")
+	}
+}
+
+func TestStoreMatchFallsThroughOnExhaustion(t *testing.T) {
+	store := NewStore()
+	sc := &Scenario{Match: Match{Tool: "limited"}, Respond: Response{Status: 200}, Times: 1}
+	store.Add(sc)
+
+	if _, ok := store.Match("limited", nil); !ok {
+		t.Fatal("first call should match")
+	}
+	if _, ok := store.Match("limited", nil); ok {
+		t.Fatal("second call should fall through once the scenario is exhausted")
+	}
+}
+
+func TestStoreAssertConsumedCatchesUnfiredScenario(t *testing.T) {
+	store := NewStore()
+	fired := &Scenario{Match: Match{Tool: "used"}, Respond: Response{Status: 200}}
+	unfired := &Scenario{Match: Match{Tool: "unused"}, Respond: Response{Status: 200}}
+	store.Add(fired, unfired)
+
+	store.Match("used", nil)
+
+	ft := &fakeT{}
+	store.AssertConsumed(ft)
+	if len(ft.errors) != 1 {
+		t.Fatalf("AssertConsumed reported %d errors, want 1 for the unfired scenario", len(ft.errors))
+	}
+}
@@ -0,0 +1,63 @@
+package scenarios
+
+import "sync"
+
+// Store holds an ordered set of Scenarios and matches tool calls against
+// them in registration order, first match wins.
+type Store struct {
+	mu        sync.Mutex
+	scenarios []*Scenario
+}
+
+// NewStore builds an empty Store.
+func NewStore() *Store {
+	return &Store{}
+}
+
+// Add registers one or more scenarios, in order, at the end of the store.
+func (s *Store) Add(scenarios ...*Scenario) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, sc := range scenarios {
+		sc.arm()
+		s.scenarios = append(s.scenarios, sc)
+	}
+}
+
+// Match finds the first scenario (in registration order) that matches tool
+// and params and still has uses remaining, consumes one use, and returns
+// its response. ok is false when nothing matched, in which case the caller
+// should fall back to default behavior (e.g. the echo response).
+func (s *Store) Match(tool string, params map[string]interface{}) (resp Response, ok bool) {
+	s.mu.Lock()
+	candidates := make([]*Scenario, len(s.scenarios))
+	copy(candidates, s.scenarios)
+	s.mu.Unlock()
+
+	for _, sc := range candidates {
+		if resp, ok = sc.tryFire(tool, params); ok {
+			return resp, true
+		}
+	}
+	return Response{}, false
+}
+
+// TestingT is the subset of *testing.T that AssertConsumed needs, so this
+// package doesn't have to import "testing" outside of _test.go files.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// AssertConsumed fails t if any registered scenario never fired, so a test
+// can catch fixtures that silently went unused.
+func (s *Store) AssertConsumed(t TestingT) {
+	t.Helper()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, sc := range s.scenarios {
+		if sc.Fired() == 0 {
+			t.Errorf("scenario %d (tool=%q) was never matched", i, sc.Match.Tool)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/jk-nd/noumena-mcp-gateway-poc/internal/cassette"
+)
+
+// runCassetteCmd implements the "cassette" subcommand, e.g.
+// "mock-mcp cassette diff a.jsonl b.jsonl" for reviewing drift between two
+// recordings in CI.
+func runCassetteCmd(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: mock-mcp cassette diff <a.jsonl> <b.jsonl>")
+		return 2
+	}
+
+	switch args[0] {
+	case "diff":
+		return runCassetteDiff(args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "unknown cassette subcommand %q\n", args[0])
+		return 2
+	}
+}
+
+func runCassetteDiff(args []string) int {
+	fs := flag.NewFlagSet("cassette diff", flag.ContinueOnError)
+	fs.Parse(args)
+	if fs.NArg() != 2 {
+		fmt.Fprintln(os.Stderr, "usage: mock-mcp cassette diff <a.jsonl> <b.jsonl>")
+		return 2
+	}
+
+	a, err := cassette.Load(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	b, err := cassette.Load(fs.Arg(1))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	diffs := cassette.Compare(a, b)
+	for _, d := range diffs {
+		fmt.Println(d.String())
+	}
+	if len(diffs) > 0 {
+		fmt.Printf("%d difference(s)\n", len(diffs))
+		return 1
+	}
+	fmt.Println("no differences")
+	return 0
+}
@@ -1,51 +1,150 @@
+// Command mock-mcp runs one or more mock MCP backends behind a shared
+// lifecycle, so the gateway can be exercised against a realistic
+// multi-endpoint topology instead of a single hardcoded process.
 package main
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
+	"flag"
 	"log"
 	"net/http"
-	"time"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/jk-nd/noumena-mcp-gateway-poc/internal/config"
+	"github.com/jk-nd/noumena-mcp-gateway-poc/internal/endpoint"
 )
 
-// Simple mock MCP server that echoes requests back
 func main() {
-	http.HandleFunc("/tools/", handleToolCall)
-	http.HandleFunc("/health", handleHealth)
+	if len(os.Args) > 1 && os.Args[1] == "cassette" {
+		os.Exit(runCassetteCmd(os.Args[2:]))
+	}
 
-	port := "8080"
-	log.Printf("Mock MCP Server starting on port %s", port)
-	log.Fatal(http.ListenAndServe(":"+port, nil))
-}
+	configPath := flag.String("config", "", "path to an endpoints YAML config (defaults to a single endpoint built from the flags below)")
+	mode := flag.String("mode", config.ModeEcho, "default endpoint mode when --config is unset: echo|record|replay|passthrough")
+	listen := flag.String("listen", config.DefaultListen, "default endpoint listen address when --config is unset")
+	upstream := flag.String("upstream", "", "real MCP upstream to call, for --mode=record|passthrough")
+	cassettePath := flag.String("cassette", "", "cassette file to read/write, for --mode=record|replay")
+	strict := flag.Bool("strict", false, "--mode=replay: answer an unknown call with a JSON-RPC -32601 error (HTTP status stays 200) instead of falling back to echo")
+	parallelBatch := flag.Bool("parallel-batch", false, "dispatch a JSON-RPC batch's requests concurrently instead of in order")
+	flag.Parse()
 
-func handleHealth(w http.ResponseWriter, r *http.Request) {
-	json.NewEncoder(w).Encode(map[string]string{
-		"status":  "ok",
-		"service": "mock-mcp",
-	})
-}
+	logger := log.Default()
+
+	cfg, err := loadConfig(*configPath, *mode, *listen, *upstream, *cassettePath, *strict, *parallelBatch)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	endpoints := make([]endpoint.Endpoint, 0, len(cfg.Endpoints))
+	for _, ec := range cfg.Endpoints {
+		ep, err := endpoint.New(ec, cfg.HTTP, logger)
+		if err != nil {
+			log.Fatal(err)
+		}
+		endpoints = append(endpoints, ep)
+	}
+
+	orchestrator := endpoint.NewOrchestrator(endpoints...)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := orchestrator.Start(ctx); err != nil {
+		log.Fatal(err)
+	}
+
+	var ready atomic.Bool
+	ready.Store(true)
 
-func handleToolCall(w http.ResponseWriter, r *http.Request) {
-	// Extract tool name from path: /tools/{tool_name}
-	toolName := r.URL.Path[len("/tools/"):]
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", orchestrator.HealthHandler())
+	mux.HandleFunc("/readyz", readyzHandler(&ready))
+	mux.Handle("/metrics", promhttp.Handler())
 
-	// Parse request body
-	var params map[string]interface{}
-	if r.Body != nil {
-		json.NewDecoder(r.Body).Decode(&params)
+	admin := &http.Server{
+		Addr:              cfg.Admin.Listen,
+		Handler:           mux,
+		ReadTimeout:       cfg.HTTP.ReadTimeout,
+		ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+		WriteTimeout:      cfg.HTTP.WriteTimeout,
+		IdleTimeout:       cfg.HTTP.IdleTimeout,
+		MaxHeaderBytes:    cfg.HTTP.MaxHeaderBytes,
 	}
 
-	log.Printf("Tool call: %s with params: %v", toolName, params)
+	adminErr := make(chan error, 1)
+	go func() {
+		logger.Printf("Mock MCP gateway serving %d endpoint(s), admin on %s", len(endpoints), cfg.Admin.Listen)
+		adminErr <- admin.ListenAndServe()
+	}()
 
-	// Return mock success response
-	response := map[string]interface{}{
-		"success":   true,
-		"tool":      toolName,
-		"timestamp": time.Now().Format(time.RFC3339),
-		"message":   fmt.Sprintf("Mock execution of '%s' completed successfully", toolName),
-		"echo":      params,
+	select {
+	case <-ctx.Done():
+		logger.Printf("shutting down")
+	case err := <-adminErr:
+		if err != nil && err != http.ErrServerClosed {
+			logger.Printf("admin server error: %v", err)
+		}
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	// Flip /readyz to 503 first so a fronting load balancer stops sending
+	// new traffic while in-flight tool calls drain.
+	ready.Store(false)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownGrace)
+	defer cancel()
+
+	if err := orchestrator.Stop(shutdownCtx); err != nil {
+		logger.Printf("endpoint shutdown: %v", err)
+	}
+	if err := admin.Shutdown(shutdownCtx); err != nil {
+		logger.Printf("admin server shutdown: %v", err)
+	}
+}
+
+func readyzHandler(ready *atomic.Bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !ready.Load() {
+			http.Error(w, "draining", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// loadConfig reads an endpoints config from path, or builds a single
+// endpoint from the quick-start flags when path is empty: an echo-mode
+// mock-mcp endpoint by default, or a record/replay/passthrough endpoint
+// when mode requests one. listen and parallelBatch always apply to that
+// single endpoint, even in the default echo mode.
+func loadConfig(path, mode, listen, upstream, cassettePath string, strict, parallelBatch bool) (*config.Config, error) {
+	if path != "" {
+		return config.Load(path)
+	}
+
+	if mode == config.ModeEcho {
+		cfg := config.Default()
+		cfg.Endpoints[0].Listen = listen
+		cfg.Endpoints[0].ParallelBatch = parallelBatch
+		return cfg, nil
+	}
+
+	cfg := &config.Config{
+		Endpoints: []config.Endpoint{{
+			Name:          "default",
+			Kind:          config.KindRecorded,
+			Listen:        listen,
+			Mode:          mode,
+			Upstream:      upstream,
+			Cassette:      cassettePath,
+			Strict:        strict,
+			ParallelBatch: parallelBatch,
+		}},
+	}
+	cfg.SetDefaults()
+	return cfg, nil
 }